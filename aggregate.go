@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type groupBy string
+
+const (
+	groupByDay         groupBy = "day"
+	groupByWeek        groupBy = "week"
+	groupByMonth       groupBy = "month"
+	groupBySummary     groupBy = "summary"
+	groupBySummaryWeek groupBy = "summary+week"
+)
+
+// bucket accumulates the columns falling into one aggregate row.
+type bucket struct {
+	label string
+	total time.Duration
+	count int
+	first string
+	last  string
+}
+
+// aggregate collapses cols into one row per bucket (as chosen by group),
+// each carrying the bucket's total duration, event count, and first/last
+// time, similar to a timesheet report.
+func aggregate(cols []Column, group groupBy) ([]Column, error) {
+	buckets := map[string]*bucket{}
+	var order []string
+
+	for _, col := range cols {
+		d, err := parseHourMinute(col.duration)
+		if err != nil {
+			return nil, err
+		}
+
+		key, label, err := bucketKey(col, group)
+		if err != nil {
+			return nil, err
+		}
+
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{label: label, first: col.startTime, last: col.endTime}
+			buckets[key] = b
+			order = append(order, key)
+		}
+
+		b.total += d
+		b.count++
+		if col.startTime < b.first {
+			b.first = col.startTime
+		}
+		if col.endTime > b.last {
+			b.last = col.endTime
+		}
+	}
+
+	sort.Strings(order)
+
+	rows := make([]Column, len(order))
+	for i, key := range order {
+		b := buckets[key]
+		rows[i] = Column{
+			name:      b.label,
+			date:      b.label,
+			startTime: b.first,
+			endTime:   b.last,
+			duration:  formatDuration(b.total),
+			count:     b.count,
+		}
+	}
+	return rows, nil
+}
+
+// bucketKey returns the map key and display label for col under group.
+func bucketKey(col Column, group groupBy) (key, label string, err error) {
+	switch group {
+	case groupByDay:
+		return col.date, col.date, nil
+	case groupByWeek:
+		w, err := isoWeekLabel(col.date)
+		return w, w, err
+	case groupByMonth:
+		m, err := monthLabel(col.date)
+		return m, m, err
+	case groupBySummary:
+		return col.summary, col.summary, nil
+	case groupBySummaryWeek:
+		w, err := isoWeekLabel(col.date)
+		if err != nil {
+			return "", "", err
+		}
+		return col.summary + "|" + w, col.summary + " " + w, nil
+	default:
+		return "", "", fmt.Errorf("unknown group-by: %s", group)
+	}
+}
+
+func isoWeekLabel(date string) (string, error) {
+	t, err := time.Parse("2006/01/02", date)
+	if err != nil {
+		return "", err
+	}
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week), nil
+}
+
+func monthLabel(date string) (string, error) {
+	t, err := time.Parse("2006/01/02", date)
+	if err != nil {
+		return "", err
+	}
+	return t.Format("2006/01"), nil
+}
+
+// parseHourMinute parses the "HH:MM" strings formatDuration produces.
+func parseHourMinute(s string) (time.Duration, error) {
+	var hours, minutes int
+	if _, err := fmt.Sscanf(s, "%d:%d", &hours, &minutes); err != nil {
+		return 0, fmt.Errorf("parsing duration %q: %w", s, err)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// filterByMinDuration drops events shorter than min.
+func filterByMinDuration(cols []Column, min time.Duration) ([]Column, error) {
+	filtered := make([]Column, 0, len(cols))
+	for _, col := range cols {
+		d, err := parseHourMinute(col.duration)
+		if err != nil {
+			return nil, err
+		}
+		if d >= min {
+			filtered = append(filtered, col)
+		}
+	}
+	return filtered, nil
+}
+
+// roundDurations snaps each event's duration to the nearest multiple of
+// round, keeping its start time and shifting its end time to match. This
+// mirrors how timesheets typically bill in fixed increments.
+func roundDurations(cols []Column, round time.Duration) ([]Column, error) {
+	if round <= 0 {
+		return cols, nil
+	}
+
+	rounded := make([]Column, len(cols))
+	for i, col := range cols {
+		d, err := parseHourMinute(col.duration)
+		if err != nil {
+			return nil, err
+		}
+
+		start, err := time.Parse("15:04", col.startTime)
+		if err != nil {
+			return nil, err
+		}
+
+		d = roundDuration(d, round)
+		col.duration = formatDuration(d)
+		col.endTime = start.Add(d).Format("15:04")
+		rounded[i] = col
+	}
+	return rounded, nil
+}
+
+func roundDuration(d, round time.Duration) time.Duration {
+	rem := d % round
+	if rem == 0 {
+		return d
+	}
+	if rem*2 >= round {
+		return d - rem + round
+	}
+	return d - rem
+}
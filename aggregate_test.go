@@ -0,0 +1,147 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketKeyDay(t *testing.T) {
+	col := Column{date: "2026/01/15"}
+	key, label, err := bucketKey(col, groupByDay)
+	if err != nil {
+		t.Fatalf("bucketKey: %v", err)
+	}
+	if key != "2026/01/15" || label != "2026/01/15" {
+		t.Errorf("got key=%q label=%q, want 2026/01/15", key, label)
+	}
+}
+
+func TestBucketKeyWeek(t *testing.T) {
+	col := Column{date: "2026/01/15"}
+	key, _, err := bucketKey(col, groupByWeek)
+	if err != nil {
+		t.Fatalf("bucketKey: %v", err)
+	}
+	if key != "2026-W03" {
+		t.Errorf("got %q, want 2026-W03", key)
+	}
+}
+
+func TestBucketKeyMonth(t *testing.T) {
+	col := Column{date: "2026/01/15"}
+	key, _, err := bucketKey(col, groupByMonth)
+	if err != nil {
+		t.Fatalf("bucketKey: %v", err)
+	}
+	if key != "2026/01" {
+		t.Errorf("got %q, want 2026/01", key)
+	}
+}
+
+func TestBucketKeySummary(t *testing.T) {
+	col := Column{summary: "Standup"}
+	key, label, err := bucketKey(col, groupBySummary)
+	if err != nil {
+		t.Fatalf("bucketKey: %v", err)
+	}
+	if key != "Standup" || label != "Standup" {
+		t.Errorf("got key=%q label=%q, want Standup", key, label)
+	}
+}
+
+func TestBucketKeySummaryWeek(t *testing.T) {
+	col := Column{summary: "Standup", date: "2026/01/15"}
+	key, label, err := bucketKey(col, groupBySummaryWeek)
+	if err != nil {
+		t.Fatalf("bucketKey: %v", err)
+	}
+	if key != "Standup|2026-W03" || label != "Standup 2026-W03" {
+		t.Errorf("got key=%q label=%q", key, label)
+	}
+}
+
+func TestBucketKeyUnknown(t *testing.T) {
+	if _, _, err := bucketKey(Column{}, groupBy("bogus")); err == nil {
+		t.Error("expected error for unknown group-by")
+	}
+}
+
+func TestParseHourMinute(t *testing.T) {
+	d, err := parseHourMinute("01:30")
+	if err != nil {
+		t.Fatalf("parseHourMinute: %v", err)
+	}
+	if d != 90*time.Minute {
+		t.Errorf("got %v, want 90m", d)
+	}
+}
+
+func TestParseHourMinuteInvalid(t *testing.T) {
+	if _, err := parseHourMinute("bogus"); err == nil {
+		t.Error("expected error for malformed duration")
+	}
+}
+
+func TestRoundDuration(t *testing.T) {
+	tests := []struct {
+		d, round, want time.Duration
+	}{
+		{50 * time.Minute, 15 * time.Minute, 45 * time.Minute},
+		{53 * time.Minute, 15 * time.Minute, 60 * time.Minute},
+		{7*time.Minute + 30*time.Second, 15 * time.Minute, 15 * time.Minute},
+		{45 * time.Minute, 15 * time.Minute, 45 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := roundDuration(tt.d, tt.round); got != tt.want {
+			t.Errorf("roundDuration(%v, %v) = %v, want %v", tt.d, tt.round, got, tt.want)
+		}
+	}
+}
+
+func TestAggregateByDay(t *testing.T) {
+	cols := []Column{
+		{date: "2026/01/16", startTime: "09:00", endTime: "10:00", duration: "01:00"},
+		{date: "2026/01/15", startTime: "13:00", endTime: "14:30", duration: "01:30"},
+		{date: "2026/01/15", startTime: "09:00", endTime: "10:00", duration: "01:00"},
+	}
+	rows, err := aggregate(cols, groupByDay)
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].date != "2026/01/15" || rows[0].duration != "02:30" || rows[0].count != 2 {
+		t.Errorf("got %+v, want date=2026/01/15 duration=02:30 count=2", rows[0])
+	}
+	if rows[0].startTime != "09:00" || rows[0].endTime != "14:30" {
+		t.Errorf("got start=%q end=%q, want 09:00/14:30", rows[0].startTime, rows[0].endTime)
+	}
+}
+
+func TestFilterByMinDuration(t *testing.T) {
+	cols := []Column{
+		{duration: "00:10"},
+		{duration: "01:00"},
+	}
+	got, err := filterByMinDuration(cols, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("filterByMinDuration: %v", err)
+	}
+	if len(got) != 1 || got[0].duration != "01:00" {
+		t.Errorf("got %+v, want only the 01:00 event", got)
+	}
+}
+
+func TestRoundDurations(t *testing.T) {
+	cols := []Column{
+		{startTime: "09:00", duration: "00:53"},
+	}
+	got, err := roundDurations(cols, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("roundDurations: %v", err)
+	}
+	if got[0].duration != "01:00" || got[0].endTime != "10:00" {
+		t.Errorf("got duration=%q end=%q, want 01:00/10:00", got[0].duration, got[0].endTime)
+	}
+}
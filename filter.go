@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+// filterField selects which ICS property -f/--filter matches against.
+type filterField string
+
+const (
+	filterFieldSummary     filterField = "summary"
+	filterFieldLocation    filterField = "location"
+	filterFieldCategories  filterField = "categories"
+	filterFieldDescription filterField = "description"
+)
+
+// eventFilter is a single compiled -f/--filter predicate. A spec prefixed
+// with "re:" or "glob:" is compiled as a regular expression or shell glob
+// respectively; anything else is matched as a plain substring.
+type eventFilter struct {
+	re     *regexp.Regexp
+	glob   string
+	substr string
+}
+
+func newEventFilter(spec string) (eventFilter, error) {
+	switch {
+	case strings.HasPrefix(spec, "re:"):
+		pattern := strings.TrimPrefix(spec, "re:")
+		if pattern == "" {
+			return eventFilter{}, fmt.Errorf("empty re: filter pattern")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return eventFilter{}, err
+		}
+		return eventFilter{re: re}, nil
+	case strings.HasPrefix(spec, "glob:"):
+		pattern := strings.TrimPrefix(spec, "glob:")
+		if pattern == "" {
+			return eventFilter{}, fmt.Errorf("empty glob: filter pattern")
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return eventFilter{}, err
+		}
+		return eventFilter{glob: pattern}, nil
+	default:
+		return eventFilter{substr: spec}, nil
+	}
+}
+
+func (f eventFilter) match(value string) bool {
+	switch {
+	case f.re != nil:
+		return f.re.MatchString(value)
+	case f.glob != "":
+		ok, _ := filepath.Match(f.glob, value)
+		return ok
+	default:
+		return strings.Contains(value, f.substr)
+	}
+}
+
+// matchesAny reports whether value satisfies at least one of filters. No
+// filters at all means everything matches.
+func matchesAny(filters []eventFilter, value string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		if f.match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldValue reads the ICS property field selects from event.
+func fieldValue(event *ics.VEvent, field filterField) string {
+	var prop string
+	switch field {
+	case filterFieldLocation:
+		prop = "LOCATION"
+	case filterFieldCategories:
+		prop = "CATEGORIES"
+	case filterFieldDescription:
+		prop = "DESCRIPTION"
+	default:
+		prop = "SUMMARY"
+	}
+	if p := event.GetProperty(ics.ComponentProperty(prop)); p != nil {
+		return p.Value
+	}
+	return ""
+}
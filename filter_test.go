@@ -0,0 +1,79 @@
+package main
+
+import "testing"
+
+func TestEventFilterSubstring(t *testing.T) {
+	f, err := newEventFilter("standup")
+	if err != nil {
+		t.Fatalf("newEventFilter: %v", err)
+	}
+	if !f.match("Daily standup") {
+		t.Error("expected substring match")
+	}
+	if f.match("1:1") {
+		t.Error("expected no match")
+	}
+}
+
+func TestEventFilterRegex(t *testing.T) {
+	f, err := newEventFilter("re:^(standup|1:1)")
+	if err != nil {
+		t.Fatalf("newEventFilter: %v", err)
+	}
+	if !f.match("standup with team") {
+		t.Error("expected regex match")
+	}
+	if f.match("not a standup") {
+		t.Error("expected no match, pattern is anchored")
+	}
+}
+
+func TestEventFilterRegexInvalid(t *testing.T) {
+	if _, err := newEventFilter("re:("); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
+
+func TestEventFilterRegexEmpty(t *testing.T) {
+	if _, err := newEventFilter("re:"); err == nil {
+		t.Error("expected error for empty re: pattern")
+	}
+}
+
+func TestEventFilterGlob(t *testing.T) {
+	f, err := newEventFilter("glob:Room-*")
+	if err != nil {
+		t.Fatalf("newEventFilter: %v", err)
+	}
+	if !f.match("Room-101") {
+		t.Error("expected glob match")
+	}
+	if f.match("Office-101") {
+		t.Error("expected no match")
+	}
+}
+
+func TestEventFilterGlobEmpty(t *testing.T) {
+	if _, err := newEventFilter("glob:"); err == nil {
+		t.Error("expected error for empty glob: pattern, not a match-everything filter")
+	}
+}
+
+func TestMatchesAnyNoFilters(t *testing.T) {
+	if !matchesAny(nil, "anything") {
+		t.Error("no filters should match everything")
+	}
+}
+
+func TestMatchesAnyOr(t *testing.T) {
+	standup, _ := newEventFilter("standup")
+	oneOnOne, _ := newEventFilter("1:1")
+	filters := []eventFilter{standup, oneOnOne}
+
+	if !matchesAny(filters, "1:1 with manager") {
+		t.Error("expected at least one filter to match")
+	}
+	if matchesAny(filters, "lunch") {
+		t.Error("expected no filter to match")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"sort"
+	"strconv"
 	"time"
 	"unicode/utf8"
 
@@ -15,45 +16,64 @@ import (
 )
 
 type CliArgs struct {
-	icsPath            string
-	outPath            string
-	eventSummaryFilter string
-	name               string
-	comma              string
-	isStdout           bool
+	icsPaths    []string
+	outPath     string
+	filters     []string
+	filterField string
+	name        string
+	comma       string
+	isStdout    bool
+	format      string
+	reverse     bool
+	tz          string
+	from        string
+	until       string
+	groupBy     string
+	minDuration string
+	round       string
 }
 
 func parseArgs() CliArgs {
 	parser := argparse.NewParser("ics2csv", "Converts an ics file to a csv file")
-	icsPath := parser.String("i", "ics", &argparse.Options{Required: true, Help: "Path to the ics file"})
+	icsPaths := parser.StringList("i", "ics", &argparse.Options{Required: true, Help: "Path to an ics file or a directory of ics files; may be given multiple times"})
 	outPath := parser.String("c", "csv", &argparse.Options{Required: false, Help: "Path to the output csv file", Default: "out.csv"})
-	eventSummaryFilter := parser.String("f", "filter", &argparse.Options{Required: false, Help: "Filter events by summary"})
+	filters := parser.StringList("f", "filter", &argparse.Options{Required: false, Help: "Keep events matching re:<pattern>, glob:<pattern>, or a substring; may be given multiple times"})
+	filterField := parser.Selector("", "filter-field", []string{"summary", "location", "categories", "description"}, &argparse.Options{Required: false, Help: "Field -f/--filter matches against", Default: "summary"})
 	name := parser.String("n", "name", &argparse.Options{Required: false, Help: "Your name", Default: "yourName"})
 	comma := parser.String("d", "delimiter", &argparse.Options{Required: false, Help: "Delimiter for csv", Default: "\t"})
 	isStdout := parser.Flag("s", "stdout", &argparse.Options{Required: false, Help: "Write to stdout instead of a file"})
+	format := parser.Selector("F", "format", []string{"csv", "tsv", "json", "frab-xml", "md"}, &argparse.Options{Required: false, Help: "Output format", Default: "tsv"})
+	reverse := parser.Flag("r", "reverse", &argparse.Options{Required: false, Help: "Read a csv/tsv file and write an ics file instead"})
+	tz := parser.String("", "tz", &argparse.Options{Required: false, Help: "IANA time zone to use for -r/--reverse, e.g. Asia/Tokyo"})
+	from := parser.String("", "from", &argparse.Options{Required: false, Help: "Expand RRULE occurrences from this date (2006/01/02), requires --until"})
+	until := parser.String("", "until", &argparse.Options{Required: false, Help: "Expand RRULE occurrences until this date (2006/01/02), requires --from"})
+	groupBy := parser.Selector("", "group-by", []string{"day", "week", "month", "summary", "summary+week"}, &argparse.Options{Required: false, Help: "Aggregate events into a timesheet-style report"})
+	minDuration := parser.String("", "min-duration", &argparse.Options{Required: false, Help: "Drop events shorter than this duration, e.g. 15m"})
+	round := parser.String("", "round", &argparse.Options{Required: false, Help: "Round each event's duration to the nearest increment before aggregating, e.g. 15m"})
 	err := parser.Parse(os.Args)
 	if err != nil {
 		fmt.Print(parser.Usage(err))
 		os.Exit(1)
 	}
 	return CliArgs{
-		*icsPath,
+		*icsPaths,
 		*outPath,
-		*eventSummaryFilter,
+		*filters,
+		*filterField,
 		*name,
 		*comma,
 		*isStdout,
+		*format,
+		*reverse,
+		*tz,
+		*from,
+		*until,
+		*groupBy,
+		*minDuration,
+		*round,
 	}
 }
 
-func parseTime(timeString string) (time.Time, error) {
-	t, err := time.Parse("20060102T150405", timeString)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return t, nil
-}
-
 func formatDuration(d time.Duration) string {
 	hours := int(d.Hours())
 	minutes := int(d.Minutes()) - hours*60
@@ -61,31 +81,63 @@ func formatDuration(d time.Duration) string {
 }
 
 type Column struct {
-	name      string
-	date      string
-	startTime string
-	endTime   string
-	duration  string
+	name        string
+	date        string
+	startTime   string
+	endTime     string
+	duration    string
+	uid         string
+	description string
+	location    string
+	summary     string
+	language    string
+	count       int
 }
 
 func NewColumn(event *ics.VEvent, name string) (Column, error) {
-	start, err := parseTime(event.GetProperty("DTSTART").Value)
+	start, end, err := eventTimes(event)
 	if err != nil {
 		return Column{}, err
 	}
+	return newColumn(event, name, start, end), nil
+}
 
-	end, err := parseTime(event.GetProperty("DTEND").Value)
-	if err != nil {
-		return Column{}, err
+// newColumn builds a Column for a single occurrence of event, given its
+// resolved start and end time. It is shared by NewColumn and by RRULE
+// expansion, which computes a distinct start/end per occurrence.
+func newColumn(event *ics.VEvent, name string, start, end time.Time) Column {
+	var uid, description, location, summary, language string
+	if p := event.GetProperty("UID"); p != nil {
+		uid = p.Value
+	}
+	if p := event.GetProperty("DESCRIPTION"); p != nil {
+		description = p.Value
+	}
+	if p := event.GetProperty("LOCATION"); p != nil {
+		location = p.Value
+	}
+	if p := event.GetProperty("SUMMARY"); p != nil {
+		summary = p.Value
+	}
+	if p := event.GetProperty("X-LANGUAGE"); p != nil {
+		language = p.Value
+	} else {
+		language = "en"
 	}
 
 	return Column{
-		name:      name,
-		date:      start.Format("2006/01/02"),
-		startTime: start.Format("15:04"),
-		endTime:   end.Format("15:04"),
-		duration:  formatDuration(end.Sub(start)),
-	}, nil
+		name:        name,
+		date:        start.Format("2006/01/02"),
+		startTime:   start.Format("15:04"),
+		endTime:     end.Format("15:04"),
+		duration:    formatDuration(end.Sub(start)),
+		uid:         uid,
+		description: description,
+		location:    location,
+		summary:     summary,
+		language:    language,
+		count:       1,
+	}
 }
 
 func writeCsv(w csv.Writer, cols []Column) error {
@@ -96,6 +148,7 @@ func writeCsv(w csv.Writer, cols []Column) error {
 			col.startTime,
 			col.endTime,
 			col.duration,
+			strconv.Itoa(col.count),
 		})
 		if err != nil {
 			return err
@@ -107,51 +160,106 @@ func writeCsv(w csv.Writer, cols []Column) error {
 func main() {
 	args := parseArgs()
 
-	// read ics
-	icsFile, err := os.Open(args.icsPath)
-	if err != nil {
-		log.Fatal(err)
+	if (args.from == "") != (args.until == "") {
+		log.Fatal("--from and --until must be given together")
 	}
-	defer func() {
-		if err = icsFile.Close(); err != nil {
+
+	if args.reverse {
+		if err := runReverse(args); err != nil {
 			log.Fatal(err)
 		}
-	}()
+		return
+	}
 
-	cal, err := ics.ParseCalendar(icsFile)
+	// read and merge ics sources
+	allEvents, err := loadEvents(args.icsPaths)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	// filter events
-	events := make([]*ics.VEvent, 0, len(cal.Events()))
+	filters := make([]eventFilter, len(args.filters))
+	for i, spec := range args.filters {
+		f, err := newEventFilter(spec)
+		if err != nil {
+			log.Fatal(err)
+		}
+		filters[i] = f
+	}
 
-	for _, event := range cal.Events() {
-		eventSummary := event.GetProperty("SUMMARY").Value
-		if args.eventSummaryFilter != "" && eventSummary != args.eventSummaryFilter {
+	events := make([]*ics.VEvent, 0, len(allEvents))
+	for _, event := range allEvents {
+		if !matchesAny(filters, fieldValue(event, filterField(args.filterField))) {
 			continue
 		}
 		events = append(events, event)
 	}
 
-	// create columns
-	columns := make([]Column, len(events))
-	for i, event := range events {
-		column, err := NewColumn(event, args.name)
+	// create columns, expanding RRULE occurrences when --from/--until are given
+	columns := make([]Column, 0, len(events))
+	for _, event := range events {
+		start, end, err := eventTimes(event)
 		if err != nil {
 			log.Fatal(err)
 		}
-		columns[i] = column
+
+		occurrences := []occurrence{{start: start, end: end}}
+		if rules, _ := event.GetRRules(); len(rules) > 0 && args.from != "" && args.until != "" {
+			from, err := time.ParseInLocation("2006/01/02", args.from, start.Location())
+			if err != nil {
+				log.Fatal(err)
+			}
+			until, err := time.ParseInLocation("2006/01/02", args.until, start.Location())
+			if err != nil {
+				log.Fatal(err)
+			}
+			until = until.Add(24*time.Hour - time.Nanosecond)
+
+			exdates, _ := event.GetExDates()
+			occurrences = expandRecurrence(rules[0], start, end.Sub(start), from, until, exdates)
+		}
+
+		for _, occ := range occurrences {
+			columns = append(columns, newColumn(event, args.name, occ.start, occ.end))
+		}
 	}
 	// sort columns by date and start time
 	sort.Slice(columns, func(i, j int) bool {
 		return columns[i].date < columns[j].date || (columns[i].date == columns[j].date && columns[i].startTime < columns[j].startTime)
 	})
 
-	// write csv
-	var writer io.Writer
+	// timesheet-style filtering, rounding, and aggregation
+	if args.minDuration != "" {
+		minDuration, err := time.ParseDuration(args.minDuration)
+		if err != nil {
+			log.Fatal(err)
+		}
+		columns, err = filterByMinDuration(columns, minDuration)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if args.round != "" {
+		round, err := time.ParseDuration(args.round)
+		if err != nil {
+			log.Fatal(err)
+		}
+		columns, err = roundDurations(columns, round)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if args.groupBy != "" {
+		columns, err = aggregate(columns, groupBy(args.groupBy))
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// write output
+	var out io.Writer
 	if args.isStdout {
-		writer = os.Stdout
+		out = os.Stdout
 	} else {
 		f, err := os.Create(args.outPath)
 		if err != nil {
@@ -162,16 +270,16 @@ func main() {
 				log.Fatal(err)
 			}
 		}()
-		writer = f
+		out = f
 	}
 
-	w := csv.NewWriter(writer)
-	c, _ := utf8.DecodeRuneInString(args.comma)
-	w.Comma = c
-	defer w.Flush()
-
-	err = writeCsv(*w, columns)
+	comma, _ := utf8.DecodeRuneInString(args.comma)
+	writer, err := writerFor(args.format, comma)
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	if err = writer.Write(out, columns); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+// loadEvents reads the ics file at each of paths -- or, for a directory,
+// every *.ics file inside it -- and merges their events, keeping only the
+// first occurrence of any UID+RECURRENCE-ID pair so the same meeting
+// appearing in more than one calendar isn't double-counted.
+func loadEvents(paths []string) ([]*ics.VEvent, error) {
+	files, err := expandIcsPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var events []*ics.VEvent
+	for _, path := range files {
+		calEvents, err := readCalendarEvents(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range calEvents {
+			// Events without a UID can't be reliably deduplicated, so
+			// always keep them rather than collapsing them together.
+			if key := dedupeKey(event); key != "" {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func readCalendarEvents(path string) ([]*ics.VEvent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cal, err := ics.ParseCalendar(f)
+	if err != nil {
+		return nil, err
+	}
+	return cal.Events(), nil
+}
+
+// expandIcsPaths replaces any directory in paths with the *.ics files it
+// directly contains.
+func expandIcsPaths(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.ics"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// dedupeKey returns a UID+RECURRENCE-ID key for event, or "" if it has no
+// UID, in which case it cannot be reliably deduplicated.
+func dedupeKey(event *ics.VEvent) string {
+	p := event.GetProperty(ics.ComponentPropertyUniqueId)
+	if p == nil || p.Value == "" {
+		return ""
+	}
+	uid := p.Value
+
+	var recurrenceID string
+	if rp := event.GetProperty(ics.ComponentPropertyRecurrenceId); rp != nil {
+		recurrenceID = rp.Value
+	}
+	return uid + "|" + recurrenceID
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+func TestDedupeKey(t *testing.T) {
+	event := ics.NewEvent("abc-123")
+	if got := dedupeKey(event); got != "abc-123|" {
+		t.Errorf("got %q, want %q", got, "abc-123|")
+	}
+
+	event.SetProperty(ics.ComponentPropertyRecurrenceId, "20260115T090000")
+	if got := dedupeKey(event); got != "abc-123|20260115T090000" {
+		t.Errorf("got %q, want %q", got, "abc-123|20260115T090000")
+	}
+}
+
+func TestDedupeKeyNoUid(t *testing.T) {
+	event := &ics.VEvent{ComponentBase: ics.ComponentBase{}}
+	if got := dedupeKey(event); got != "" {
+		t.Errorf("got %q, want empty string for a UID-less event", got)
+	}
+}
+
+const testIcsTemplate = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:%s
+DTSTART:20260115T090000
+DTEND:20260115T100000
+SUMMARY:%s
+END:VEVENT
+END:VCALENDAR
+`
+
+func writeTestIcs(t *testing.T, dir, name, uid, summary string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	content := []byte(fmt.Sprintf(testIcsTemplate, uid, summary))
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadEventsDedupesAcrossFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestIcs(t, dir, "work.ics", "shared-uid", "Standup")
+	writeTestIcs(t, dir, "personal.ics", "shared-uid", "Standup (duplicate)")
+
+	events, err := loadEvents([]string{filepath.Join(dir, "work.ics"), filepath.Join(dir, "personal.ics")})
+	if err != nil {
+		t.Fatalf("loadEvents: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1 (deduped by UID)", len(events))
+	}
+}
+
+func TestLoadEventsFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeTestIcs(t, dir, "a.ics", "uid-a", "A")
+	writeTestIcs(t, dir, "b.ics", "uid-b", "B")
+
+	events, err := loadEvents([]string{dir})
+	if err != nil {
+		t.Fatalf("loadEvents: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+}
+
+func TestExpandIcsPathsMissing(t *testing.T) {
+	if _, err := expandIcsPaths([]string{"/no/such/path.ics"}); err == nil {
+		t.Error("expected error for a missing path")
+	}
+}
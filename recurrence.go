@@ -0,0 +1,182 @@
+package main
+
+import (
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+// occurrence is a single instance of a (possibly recurring) event.
+type occurrence struct {
+	start time.Time
+	end   time.Time
+}
+
+// expandRecurrence expands rule starting at start into individual
+// occurrences within [windowFrom, windowUntil], dropping any that match an
+// EXDATE. It covers FREQ=DAILY|WEEKLY|MONTHLY with INTERVAL, COUNT, UNTIL,
+// and BYDAY (BYDAY only affects WEEKLY; other frequencies keep the
+// DTSTART weekday/day-of-month).
+func expandRecurrence(rule *ics.RecurrenceRule, start time.Time, duration time.Duration, windowFrom, windowUntil time.Time, exdates []time.Time) []occurrence {
+	limit := windowUntil
+	if !rule.Until.IsZero() && rule.Until.Before(limit) {
+		limit = rule.Until
+	}
+
+	excluded := make(map[string]bool, len(exdates))
+	for _, ex := range exdates {
+		excluded[ex.Format(time.RFC3339)] = true
+	}
+
+	interval := rule.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var occurrences []occurrence
+	raw := 0
+	// emit reports whether generation should continue past t.
+	emit := func(t time.Time) bool {
+		if t.After(limit) {
+			return false
+		}
+		raw++
+		if rule.Count > 0 && raw > rule.Count {
+			return false
+		}
+		if !t.Before(windowFrom) && !excluded[t.Format(time.RFC3339)] {
+			occurrences = append(occurrences, occurrence{start: t, end: t.Add(duration)})
+		}
+		return true
+	}
+
+	switch rule.Freq {
+	case ics.FrequencyDaily:
+		for t := start; emit(t); t = t.AddDate(0, 0, interval) {
+		}
+	case ics.FrequencyWeekly:
+		expandWeekly(rule, start, interval, emit)
+	case ics.FrequencyMonthly:
+		expandMonthly(start, interval, limit, emit)
+	default:
+		emit(start)
+	}
+
+	return occurrences
+}
+
+// expandMonthly walks month by month from start, stepping by interval
+// months and emitting an occurrence on start's day-of-month. It anchors
+// on the day explicitly rather than using time.Time.AddDate, which rolls
+// an out-of-range day (e.g. day 31 in a 30-day month) over into the
+// following month; per RFC 5545 such months are skipped instead.
+func expandMonthly(start time.Time, interval int, limit time.Time, emit func(time.Time) bool) {
+	for n := 0; ; n++ {
+		t, ok := addMonthClamped(start, n*interval)
+		if !ok {
+			// This month has no such day (e.g. Feb 31st); skip it, but
+			// still stop once we've walked past the window so a rule
+			// anchored on the 31st doesn't loop forever.
+			if firstOfMonth(start).AddDate(0, n*interval, 0).After(limit) {
+				return
+			}
+			continue
+		}
+		if !emit(t) {
+			return
+		}
+	}
+}
+
+// addMonthClamped returns start's day-of-month, months later, or false if
+// that month is too short to contain the day.
+func addMonthClamped(start time.Time, months int) (time.Time, bool) {
+	first := firstOfMonth(start).AddDate(0, months, 0)
+	lastDayOfMonth := first.AddDate(0, 1, -1).Day()
+	if start.Day() > lastDayOfMonth {
+		return time.Time{}, false
+	}
+	return time.Date(first.Year(), first.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, start.Location()), true
+}
+
+// firstOfMonth returns midnight on the first of t's month.
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// expandWeekly walks week by week from the Monday of start's week,
+// stepping by interval weeks and emitting one occurrence per matching
+// BYDAY weekday (or start's own weekday if BYDAY is unset).
+func expandWeekly(rule *ics.RecurrenceRule, start time.Time, interval int, emit func(time.Time) bool) {
+	weekdays := ruleWeekdays(rule, start)
+	weekStart := mondayOf(start)
+
+	for w := 0; ; w++ {
+		base := weekStart.AddDate(0, 0, 7*interval*w)
+
+		continuing := true
+		for _, wd := range weekdays {
+			t := alignToWeekday(base, wd, start)
+			if t.Before(start) {
+				continue
+			}
+			if !emit(t) {
+				continuing = false
+				break
+			}
+		}
+		if !continuing {
+			break
+		}
+	}
+}
+
+// ruleWeekdays returns the weekdays a WEEKLY rule recurs on, defaulting to
+// start's own weekday when BYDAY is absent.
+func ruleWeekdays(rule *ics.RecurrenceRule, start time.Time) []time.Weekday {
+	weekdays := make([]time.Weekday, 0, len(rule.ByDay))
+	for _, wdn := range rule.ByDay {
+		if wd, ok := icsWeekday(wdn.Day); ok {
+			weekdays = append(weekdays, wd)
+		}
+	}
+	if len(weekdays) == 0 {
+		return []time.Weekday{start.Weekday()}
+	}
+	return weekdays
+}
+
+func icsWeekday(d ics.Weekday) (time.Weekday, bool) {
+	switch d {
+	case ics.WeekdaySunday:
+		return time.Sunday, true
+	case ics.WeekdayMonday:
+		return time.Monday, true
+	case ics.WeekdayTuesday:
+		return time.Tuesday, true
+	case ics.WeekdayWednesday:
+		return time.Wednesday, true
+	case ics.WeekdayThursday:
+		return time.Thursday, true
+	case ics.WeekdayFriday:
+		return time.Friday, true
+	case ics.WeekdaySaturday:
+		return time.Saturday, true
+	default:
+		return 0, false
+	}
+}
+
+// mondayOf returns midnight on the Monday of the week containing t.
+func mondayOf(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, -offset)
+}
+
+// alignToWeekday returns the given weekday within weekMonday's week, at
+// the same time of day as base.
+func alignToWeekday(weekMonday time.Time, wd time.Weekday, base time.Time) time.Time {
+	offset := (int(wd) + 6) % 7 // days since Monday
+	d := weekMonday.AddDate(0, 0, offset)
+	return time.Date(d.Year(), d.Month(), d.Day(), base.Hour(), base.Minute(), base.Second(), 0, base.Location())
+}
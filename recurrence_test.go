@@ -0,0 +1,108 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+func mustParseRule(t *testing.T, s string) *ics.RecurrenceRule {
+	t.Helper()
+	rule, err := ics.ParseRecurrenceRule(s)
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule(%q): %v", s, err)
+	}
+	return rule
+}
+
+func dates(t *testing.T, occurrences []occurrence) []string {
+	t.Helper()
+	out := make([]string, len(occurrences))
+	for i, occ := range occurrences {
+		out[i] = occ.start.Format("2006-01-02")
+	}
+	return out
+}
+
+func TestExpandRecurrenceDaily(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule := mustParseRule(t, "FREQ=DAILY;COUNT=3")
+	window := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := dates(t, expandRecurrence(rule, start, time.Hour, start, window, nil))
+	want := []string{"2026-01-01", "2026-01-02", "2026-01-03"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestExpandRecurrenceDailyUntil(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule := mustParseRule(t, "FREQ=DAILY;UNTIL=20260103T235959Z")
+	window := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := dates(t, expandRecurrence(rule, start, time.Hour, start, window, nil))
+	want := []string{"2026-01-01", "2026-01-02", "2026-01-03"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestExpandRecurrenceWeeklyByDay(t *testing.T) {
+	// Thursday 2026-01-01
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule := mustParseRule(t, "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4")
+	window := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := dates(t, expandRecurrence(rule, start, time.Hour, start, window, nil))
+	want := []string{"2026-01-01", "2026-01-06", "2026-01-08", "2026-01-13"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestExpandRecurrenceMonthlyEndOfMonth(t *testing.T) {
+	// Regression test: DTSTART on the 31st must not drift forward when a
+	// short month is stepped over (AddDate's month-overflow rollover).
+	start := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+	rule := mustParseRule(t, "FREQ=MONTHLY;COUNT=5")
+	window := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := dates(t, expandRecurrence(rule, start, time.Hour, start, window, nil))
+	want := []string{"2026-01-31", "2026-03-31", "2026-05-31", "2026-07-31", "2026-08-31"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestExpandRecurrenceExdate(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule := mustParseRule(t, "FREQ=DAILY;COUNT=3")
+	window := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	exdates := []time.Time{time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)}
+
+	got := dates(t, expandRecurrence(rule, start, time.Hour, start, window, exdates))
+	want := []string{"2026-01-01", "2026-01-03"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
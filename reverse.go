@@ -0,0 +1,121 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+// newUid derives a stable UID from an event's name, date and start time so
+// that repeated ics2tsv -> tsv2ics conversions of the same row always
+// produce the same UID.
+func newUid(name, date, startTime string) string {
+	sum := sha1.Sum([]byte(name + "|" + date + "|" + startTime))
+	return hex.EncodeToString(sum[:]) + "@ics2tsv"
+}
+
+// parseRowTime parses the date/time columns produced by writeCsv back into
+// a time.Time, optionally in the given IANA time zone.
+func parseRowTime(date, clock, tz string) (time.Time, error) {
+	if tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.ParseInLocation("2006/01/02 15:04", date+" "+clock, loc)
+	}
+	return time.Parse("2006/01/02 15:04", date+" "+clock)
+}
+
+// reverseCsv reads rows in the schema produced by writeCsv from r and
+// returns the equivalent ics.Calendar.
+func reverseCsv(r csv.Reader, tz string) (*ics.Calendar, error) {
+	cal := ics.NewCalendar()
+	now := time.Now()
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 5 {
+			return nil, fmt.Errorf("row has too few columns: %v", record)
+		}
+		name, date, startTime, duration := record[0], record[1], record[2], record[4]
+
+		start, err := parseRowTime(date, startTime, tz)
+		if err != nil {
+			return nil, err
+		}
+		d, err := parseHourMinute(duration)
+		if err != nil {
+			return nil, err
+		}
+		// Derive DTEND from DTSTART+duration rather than combining the
+		// endTime column with the same date column: an all-day event's
+		// endTime equals its startTime (00:00) and an overnight event's
+		// endTime is on the following day, so pairing either with date
+		// alone would produce a zero-length or negative-length event.
+		end := start.Add(d)
+
+		event := ics.NewEvent(newUid(name, date, startTime))
+		event.SetDtStampTime(now)
+		event.SetSummary(name)
+		if tz != "" {
+			event.SetProperty(ics.ComponentPropertyDtStart, start.Format("20060102T150405"), ics.WithTZID(tz))
+			event.SetProperty(ics.ComponentPropertyDtEnd, end.Format("20060102T150405"), ics.WithTZID(tz))
+		} else {
+			// Write floating (no TZID, no Z suffix) times so the output
+			// round-trips through ics2tsv's plain parseTime layout.
+			event.SetProperty(ics.ComponentPropertyDtStart, start.Format("20060102T150405"))
+			event.SetProperty(ics.ComponentPropertyDtEnd, end.Format("20060102T150405"))
+		}
+		cal.AddVEvent(event)
+	}
+
+	return cal, nil
+}
+
+// runReverse converts a TSV/CSV file back into an .ics calendar, the
+// inverse of the default ics2tsv direction. It writes to the first
+// -i/--ics path (required by parseArgs), ignoring any further ones.
+func runReverse(args CliArgs) error {
+	var in io.Reader
+	if args.isStdout {
+		in = os.Stdin
+	} else {
+		f, err := os.Open(args.outPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	r := csv.NewReader(in)
+	comma, _ := utf8.DecodeRuneInString(args.comma)
+	r.Comma = comma
+
+	cal, err := reverseCsv(*r, args.tz)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(args.icsPaths[0])
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	return cal.SerializeTo(out)
+}
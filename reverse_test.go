@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+// roundTrip runs csv text through reverseCsv, then re-derives the TSV
+// columns from the resulting calendar, mimicking ics2tsv -> tsv2ics ->
+// ics2tsv.
+func roundTrip(t *testing.T, row []string) Column {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(row); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	w.Flush()
+
+	r := csv.NewReader(strings.NewReader(buf.String()))
+	cal, err := reverseCsv(*r, "")
+	if err != nil {
+		t.Fatalf("reverseCsv: %v", err)
+	}
+
+	events := cal.Events()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	col, err := NewColumn(events[0], row[0])
+	if err != nil {
+		t.Fatalf("NewColumn: %v", err)
+	}
+	return col
+}
+
+func TestReverseRoundTripAllDay(t *testing.T) {
+	// An all-day event: 00:00 -> 00:00, 24:00 duration.
+	row := []string{"Alice", "2026/01/15", "00:00", "00:00", "24:00", "1"}
+	col := roundTrip(t, row)
+
+	if col.date != "2026/01/15" || col.startTime != "00:00" || col.endTime != "00:00" || col.duration != "24:00" {
+		t.Errorf("got %+v, want date=2026/01/15 start=00:00 end=00:00 duration=24:00", col)
+	}
+}
+
+func TestReverseRoundTripOvernight(t *testing.T) {
+	// An overnight event: 23:00 -> 01:00 (next day), 02:00 duration.
+	row := []string{"Bob", "2026/01/15", "23:00", "01:00", "02:00", "1"}
+	col := roundTrip(t, row)
+
+	if col.date != "2026/01/15" || col.startTime != "23:00" || col.endTime != "01:00" || col.duration != "02:00" {
+		t.Errorf("got %+v, want date=2026/01/15 start=23:00 end=01:00 duration=02:00", col)
+	}
+}
+
+func TestReverseRoundTripSameDay(t *testing.T) {
+	row := []string{"Carol", "2026/01/15", "09:00", "10:30", "01:30", "1"}
+	col := roundTrip(t, row)
+
+	if col.date != "2026/01/15" || col.startTime != "09:00" || col.endTime != "10:30" || col.duration != "01:30" {
+		t.Errorf("got %+v, want date=2026/01/15 start=09:00 end=10:30 duration=01:30", col)
+	}
+}
+
+func TestReverseCsvTooFewColumns(t *testing.T) {
+	r := csv.NewReader(strings.NewReader("Alice,2026/01/15,09:00,10:00\n"))
+	if _, err := reverseCsv(*r, ""); err == nil {
+		t.Error("expected error for row missing the duration column")
+	}
+}
+
+func TestNewUidStable(t *testing.T) {
+	a := newUid("Alice", "2026/01/15", "09:00")
+	b := newUid("Alice", "2026/01/15", "09:00")
+	if a != b {
+		t.Errorf("newUid not stable: %q != %q", a, b)
+	}
+	if c := newUid("Alice", "2026/01/15", "10:00"); c == a {
+		t.Error("newUid did not vary with startTime")
+	}
+}
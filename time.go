@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+// parseTime parses an ICS DTSTART/DTEND value, taking into account the
+// VALUE=DATE and TZID parameters that may accompany it. It tries, in
+// order, an all-day date, a UTC timestamp, and a floating timestamp.
+func parseTime(value string, params PropertyParams) (time.Time, error) {
+	if isAllDay(params) {
+		return time.Parse("20060102", value)
+	}
+
+	if tzid := params.Get("TZID"); tzid != "" {
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("loading TZID %q: %w", tzid, err)
+		}
+		return time.ParseInLocation("20060102T150405", value, loc)
+	}
+
+	for _, layout := range []string{"20060102", "20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized time format: %q", value)
+}
+
+// PropertyParams is a thin accessor over the ICalParameters map golang-ical
+// attaches to a property, so callers don't have to deal with its
+// map[string][]string shape directly.
+type PropertyParams map[string][]string
+
+func (p PropertyParams) Get(key string) string {
+	if vs, ok := p[key]; ok && len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+func isAllDay(params PropertyParams) bool {
+	return params.Get("VALUE") == "DATE"
+}
+
+var isoDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseISODuration parses an RFC 5545 DURATION value, e.g. "PT1H30M" or
+// "-P1DT2H".
+func parseISODuration(value string) (time.Duration, error) {
+	m := isoDurationPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, fmt.Errorf("unrecognized duration: %q", value)
+	}
+
+	var d time.Duration
+	if m[2] != "" {
+		days, _ := strconv.Atoi(m[2])
+		d += time.Duration(days) * 24 * time.Hour
+	}
+	if m[3] != "" {
+		hours, _ := strconv.Atoi(m[3])
+		d += time.Duration(hours) * time.Hour
+	}
+	if m[4] != "" {
+		minutes, _ := strconv.Atoi(m[4])
+		d += time.Duration(minutes) * time.Minute
+	}
+	if m[5] != "" {
+		seconds, _ := strconv.Atoi(m[5])
+		d += time.Duration(seconds) * time.Second
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// eventTimes resolves an event's start and end time, computing end from
+// DURATION when DTEND is absent as allowed by RFC 5545.
+func eventTimes(event *ics.VEvent) (start, end time.Time, err error) {
+	startProp := event.GetProperty("DTSTART")
+	if startProp == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("event is missing DTSTART")
+	}
+	start, err = parseTime(startProp.Value, startProp.ICalParameters)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if endProp := event.GetProperty("DTEND"); endProp != nil {
+		end, err = parseTime(endProp.Value, endProp.ICalParameters)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return start, end, nil
+	}
+
+	if durationProp := event.GetProperty("DURATION"); durationProp != nil {
+		d, err := parseISODuration(durationProp.Value)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return start, start.Add(d), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("event has neither DTEND nor DURATION")
+}
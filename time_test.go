@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+func TestParseTimeAllDay(t *testing.T) {
+	got, err := parseTime("20260115", PropertyParams{"VALUE": {"DATE"}})
+	if err != nil {
+		t.Fatalf("parseTime: %v", err)
+	}
+	want := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseTimeUTC(t *testing.T) {
+	got, err := parseTime("20260115T090000Z", PropertyParams{})
+	if err != nil {
+		t.Fatalf("parseTime: %v", err)
+	}
+	if got.Hour() != 9 || got.Location() != time.UTC {
+		t.Errorf("got %v, want 09:00 UTC", got)
+	}
+}
+
+func TestParseTimeTZID(t *testing.T) {
+	got, err := parseTime("20260115T090000", PropertyParams{"TZID": {"Asia/Tokyo"}})
+	if err != nil {
+		t.Fatalf("parseTime: %v", err)
+	}
+	if name, _ := got.Zone(); name != "JST" {
+		t.Errorf("got zone %v, want JST", name)
+	}
+	if got.Hour() != 9 {
+		t.Errorf("got hour %v, want 9", got.Hour())
+	}
+}
+
+func TestParseTimeFloating(t *testing.T) {
+	got, err := parseTime("20260115T090000", PropertyParams{})
+	if err != nil {
+		t.Fatalf("parseTime: %v", err)
+	}
+	if got.Hour() != 9 || got.Minute() != 0 {
+		t.Errorf("got %v, want 09:00", got)
+	}
+}
+
+func TestEventTimesDuration(t *testing.T) {
+	event := ics.NewEvent("test-uid")
+	event.SetProperty("DTSTART", "20260115T090000Z")
+	event.SetProperty("DURATION", "PT1H30M")
+
+	start, end, err := eventTimes(event)
+	if err != nil {
+		t.Fatalf("eventTimes: %v", err)
+	}
+	if d := end.Sub(start); d != 90*time.Minute {
+		t.Errorf("got duration %v, want 90m", d)
+	}
+}
+
+func TestEventTimesMissingEnd(t *testing.T) {
+	event := ics.NewEvent("test-uid")
+	event.SetProperty("DTSTART", "20260115T090000Z")
+
+	if _, _, err := eventTimes(event); err == nil {
+		t.Error("expected error for event with neither DTEND nor DURATION")
+	}
+}
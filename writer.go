@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Writer writes a slice of Column to w in some output format.
+type Writer interface {
+	Write(w io.Writer, cols []Column) error
+}
+
+// CsvWriter writes columns as delimiter-separated values (csv or tsv).
+type CsvWriter struct {
+	Comma rune
+}
+
+func (cw CsvWriter) Write(w io.Writer, cols []Column) error {
+	csvW := csv.NewWriter(w)
+	csvW.Comma = cw.Comma
+	if err := writeCsv(*csvW, cols); err != nil {
+		return err
+	}
+	csvW.Flush()
+	return csvW.Error()
+}
+
+// JsonWriter writes columns as a JSON array, including the raw ICS
+// UID and description so downstream tooling can consume the output
+// without reparsing the original ics file.
+type JsonWriter struct{}
+
+type jsonEvent struct {
+	Name        string `json:"name"`
+	Date        string `json:"date"`
+	StartTime   string `json:"start_time"`
+	EndTime     string `json:"end_time"`
+	Duration    string `json:"duration"`
+	Count       int    `json:"count"`
+	Uid         string `json:"uid"`
+	Description string `json:"description"`
+}
+
+func (JsonWriter) Write(w io.Writer, cols []Column) error {
+	events := make([]jsonEvent, len(cols))
+	for i, col := range cols {
+		events[i] = jsonEvent{
+			Name:        col.name,
+			Date:        col.date,
+			StartTime:   col.startTime,
+			EndTime:     col.endTime,
+			Duration:    col.duration,
+			Count:       col.count,
+			Uid:         col.uid,
+			Description: col.description,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(events)
+}
+
+// MarkdownWriter writes columns as a GitHub-flavored Markdown table.
+type MarkdownWriter struct{}
+
+func (MarkdownWriter) Write(w io.Writer, cols []Column) error {
+	if _, err := fmt.Fprintln(w, "| Name | Date | Start | End | Duration | Count |"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "| --- | --- | --- | --- | --- | --- |"); err != nil {
+		return err
+	}
+	for _, col := range cols {
+		if _, err := fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %d |\n", col.name, col.date, col.startTime, col.endTime, col.duration, col.count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FrabXmlWriter writes columns as an Infobeamer/Frab-style XML schedule,
+// grouping events into day buckets bounded by the min/max date.
+type FrabXmlWriter struct{}
+
+type frabSchedule struct {
+	XMLName    xml.Name       `xml:"schedule"`
+	Conference frabConference `xml:"conference"`
+	Days       []frabDay      `xml:"day"`
+}
+
+type frabConference struct {
+	Title string `xml:"title"`
+}
+
+type frabDay struct {
+	Index int        `xml:"index,attr"`
+	Date  string     `xml:"date,attr"`
+	Rooms []frabRoom `xml:"room"`
+}
+
+type frabRoom struct {
+	Name   string      `xml:"name,attr"`
+	Events []frabEvent `xml:"event"`
+}
+
+type frabEvent struct {
+	Guid     string `xml:"guid,attr"`
+	Start    string `xml:"start"`
+	Duration string `xml:"duration"`
+	Title    string `xml:"title"`
+	Language string `xml:"language"`
+}
+
+func (FrabXmlWriter) Write(w io.Writer, cols []Column) error {
+	sched := frabSchedule{Conference: frabConference{Title: "Schedule"}}
+
+	if len(cols) > 0 {
+		dates := make([]string, len(cols))
+		for i, col := range cols {
+			dates[i] = col.date
+		}
+		sort.Strings(dates)
+		minDate, maxDate := dates[0], dates[len(dates)-1]
+
+		roomsByDate := map[string]map[string][]frabEvent{}
+		for _, col := range cols {
+			room := col.location
+			if room == "" {
+				room = "default"
+			}
+			ev := frabEvent{
+				Guid:     col.uid,
+				Start:    col.startTime,
+				Duration: col.duration,
+				Title:    col.summary,
+				Language: col.language,
+			}
+			if _, ok := roomsByDate[col.date]; !ok {
+				roomsByDate[col.date] = map[string][]frabEvent{}
+			}
+			roomsByDate[col.date][room] = append(roomsByDate[col.date][room], ev)
+		}
+
+		index := 1
+		for date := minDate; date <= maxDate; index++ {
+			rooms, ok := roomsByDate[date]
+			if ok {
+				day := frabDay{Index: index, Date: date}
+				roomNames := make([]string, 0, len(rooms))
+				for name := range rooms {
+					roomNames = append(roomNames, name)
+				}
+				sort.Strings(roomNames)
+				for _, name := range roomNames {
+					day.Rooms = append(day.Rooms, frabRoom{Name: name, Events: rooms[name]})
+				}
+				sched.Days = append(sched.Days, day)
+			}
+			if date == maxDate {
+				break
+			}
+			date = nextDate(date)
+		}
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(sched)
+}
+
+// nextDate returns the day after date, both formatted as "2006/01/02".
+func nextDate(date string) string {
+	t, err := time.Parse("2006/01/02", date)
+	if err != nil {
+		return date
+	}
+	return t.AddDate(0, 0, 1).Format("2006/01/02")
+}
+
+// writerFor returns the Writer for the given -F/--format value.
+func writerFor(format string, comma rune) (Writer, error) {
+	switch format {
+	case "csv":
+		return CsvWriter{Comma: ','}, nil
+	case "tsv":
+		return CsvWriter{Comma: comma}, nil
+	case "json":
+		return JsonWriter{}, nil
+	case "frab-xml":
+		return FrabXmlWriter{}, nil
+	case "md":
+		return MarkdownWriter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown format: %s", format)
+	}
+}
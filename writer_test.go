@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestNextDate(t *testing.T) {
+	got := nextDate("2026/01/31")
+	if got != "2026/02/01" {
+		t.Errorf("got %q, want 2026/02/01", got)
+	}
+}
+
+func TestNextDateInvalid(t *testing.T) {
+	if got := nextDate("not-a-date"); got != "not-a-date" {
+		t.Errorf("got %q, want input echoed back", got)
+	}
+}
+
+func TestFrabXmlWriterTitleAndLanguage(t *testing.T) {
+	cols := []Column{
+		{name: "Alice", summary: "Japanese talk", language: "ja", date: "2026/01/15", startTime: "10:00", duration: "01:00", location: "Room A", uid: "uid-1"},
+	}
+
+	var buf bytes.Buffer
+	if err := (FrabXmlWriter{}).Write(&buf, cols); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var sched frabSchedule
+	if err := xml.Unmarshal(buf.Bytes(), &sched); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(sched.Days) != 1 || len(sched.Days[0].Rooms) != 1 || len(sched.Days[0].Rooms[0].Events) != 1 {
+		t.Fatalf("unexpected schedule shape: %+v", sched)
+	}
+	ev := sched.Days[0].Rooms[0].Events[0]
+	if ev.Title != "Japanese talk" {
+		t.Errorf("got title %q, want %q (event's SUMMARY, not attendee name)", ev.Title, "Japanese talk")
+	}
+	if ev.Language != "ja" {
+		t.Errorf("got language %q, want %q", ev.Language, "ja")
+	}
+}
+
+func TestFrabXmlWriterLanguageDefault(t *testing.T) {
+	cols := []Column{
+		{name: "Alice", summary: "Standup", language: "en", date: "2026/01/15", startTime: "09:00", duration: "00:15"},
+	}
+
+	var buf bytes.Buffer
+	if err := (FrabXmlWriter{}).Write(&buf, cols); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<language>en</language>") {
+		t.Errorf("expected default language en in output, got %s", buf.String())
+	}
+}
+
+func TestFrabXmlWriterDayBucketing(t *testing.T) {
+	cols := []Column{
+		{name: "Alice", summary: "Day 1 talk", language: "en", date: "2026/01/15", startTime: "09:00", duration: "01:00"},
+		{name: "Bob", summary: "Day 3 talk", language: "en", date: "2026/01/17", startTime: "09:00", duration: "01:00"},
+	}
+
+	var buf bytes.Buffer
+	if err := (FrabXmlWriter{}).Write(&buf, cols); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var sched frabSchedule
+	if err := xml.Unmarshal(buf.Bytes(), &sched); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	// The empty middle day (01/16) has no events and should be dropped,
+	// leaving two day buckets indexed 1 and 2.
+	if len(sched.Days) != 2 {
+		t.Fatalf("got %d days, want 2 (empty middle day dropped): %+v", len(sched.Days), sched.Days)
+	}
+	if sched.Days[0].Date != "2026/01/15" || sched.Days[1].Date != "2026/01/17" {
+		t.Errorf("got dates %q, %q, want 2026/01/15, 2026/01/17", sched.Days[0].Date, sched.Days[1].Date)
+	}
+	// index counts calendar days from minDate, including the empty one
+	// dropped above, so day 2026/01/17 is index 3.
+	if sched.Days[0].Index != 1 || sched.Days[1].Index != 3 {
+		t.Errorf("got indexes %d, %d, want 1, 3", sched.Days[0].Index, sched.Days[1].Index)
+	}
+}
+
+func TestMarkdownWriter(t *testing.T) {
+	cols := []Column{
+		{name: "Alice", date: "2026/01/15", startTime: "09:00", endTime: "10:00", duration: "01:00", count: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := (MarkdownWriter{}).Write(&buf, cols); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "| Alice | 2026/01/15 | 09:00 | 10:00 | 01:00 | 1 |") {
+		t.Errorf("unexpected markdown output: %s", buf.String())
+	}
+}
+
+func TestWriterForUnknownFormat(t *testing.T) {
+	if _, err := writerFor("bogus", ','); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}